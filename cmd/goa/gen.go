@@ -114,9 +114,10 @@ func (g *Generator) Write(debug bool) error {
 	var sections []*codegen.SectionTemplate
 	{
 		data := map[string]interface{}{
-			"Command":       g.Command,
-			"CleanupDirs":   cleanupDirs(g.Command, g.Output),
-			"DesignVersion": g.DesignVersion,
+			"Command":        g.Command,
+			"CleanupDirs":    cleanupDirs(g.Command, g.Output),
+			"CleanupVersion": generateCleanupVersion,
+			"DesignVersion":  g.DesignVersion,
 		}
 		ver := ""
 		if g.DesignVersion > 2 {
@@ -252,6 +253,12 @@ func (g *Generator) runGoCmd(args ...string) error {
 	return nil
 }
 
+// generateCleanupVersion is the first version of the goa library whose
+// generator.Generate deletes the stale subdirectories under gendir once all
+// the files have been generated. The generator main only deletes them prior to
+// generating code when it is compiled against an older version of the library.
+var generateCleanupVersion = [3]int{3, 5, 6}
+
 // cleanupDirs returns the paths of the subdirectories under gendir to delete
 // before generating code.
 func cleanupDirs(cmd, output string) []string {
@@ -312,9 +319,13 @@ const mainT = `func main() {
 	if err := eval.RunDSL(); err != nil {
 		fail(err.Error())
 	}
-{{- range .CleanupDirs }}
-	if err := os.RemoveAll({{ printf "%q" . }}); err != nil {
-		fail(err.Error())
+{{- if .CleanupDirs }}
+	if !libraryVersionAtLeast({{ range $i, $v := .CleanupVersion }}{{ if $i }}, {{ end }}{{ $v }}{{ end }}) {
+	{{- range .CleanupDirs }}
+		if err := os.RemoveAll({{ printf "%q" . }}); err != nil {
+			fail(err.Error())
+		}
+	{{- end }}
 	}
 {{- end }}
 {{- if gt .DesignVersion 2 }}
@@ -332,4 +343,18 @@ func fail(msg string, vals ...interface{}) {
 	fmt.Fprintf(os.Stderr, msg, vals...)
 	os.Exit(1)
 }
+{{- if .CleanupDirs }}
+
+// libraryVersionAtLeast returns true if the version of the goa library the
+// generator is compiled against is greater than or equal to the given version.
+func libraryVersionAtLeast(major, minor, build int) bool {
+	if goa.Major != major {
+		return goa.Major > major
+	}
+	if goa.Minor != minor {
+		return goa.Minor > minor
+	}
+	return goa.Build >= build
+}
+{{- end }}
 `
@@ -36,7 +36,11 @@ type (
 		// already exists at the given path.
 		SkipExist bool
 		// FinalizeFunc is called after the file has been generated. It
-		// is given the absolute path to the file as argument.
+		// is given the absolute path to the file as argument. When the
+		// file is generated by generator.Generate the path is inside a
+		// temporary directory whose content is moved to the output
+		// directory once all the files have been generated, so the path
+		// should not be retained.
 		FinalizeFunc func(string) error
 	}
 
@@ -97,7 +101,8 @@ func (f *File) Render(dir string) (string, error) {
 	}
 	for _, s := range f.SectionTemplates {
 		if err := s.Write(file); err != nil {
-			return "", err
+			file.Close()
+			return "", fmt.Errorf("failed to render section %q of %s: %w", s.Name, f.Path, err)
 		}
 	}
 	if err := file.Close(); err != nil {
@@ -106,8 +111,8 @@ func (f *File) Render(dir string) (string, error) {
 
 	// Format Go source files
 	if filepath.Ext(path) == ".go" {
-		if err := finalizeGoSource(path); err != nil {
-			return "", err
+		if err := finalizeGoSource(path, f.Path); err != nil {
+			return "", fmt.Errorf("failed to format Go source: %w", err)
 		}
 	}
 
@@ -132,13 +137,17 @@ func (s *SectionTemplate) Write(w io.Writer) error {
 }
 
 // finalizeGoSource removes unneeded imports from the given Go source file and
-// runs go fmt on it.
-func finalizeGoSource(path string) error {
+// runs go fmt on it. name is the filename used to report errors, it prefixes
+// the error positions or the error message if there is no position.
+func finalizeGoSource(path, name string) error {
 	// Make sure file parses and print content if it does not.
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
 	fset := token.NewFileSet()
-	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	file, err := parser.ParseFile(fset, name, content, parser.ParseComments)
 	if err != nil {
-		content, _ := ioutil.ReadFile(path)
 		var buf bytes.Buffer
 		scanner.PrintError(&buf, err)
 		return fmt.Errorf("%s\n========\nContent:\n%s", buf.String(), content)
@@ -161,25 +170,28 @@ func finalizeGoSource(path string) error {
 	ast.SortImports(fset, file)
 	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
 	if err != nil {
-		return err
+		return fmt.Errorf("%s: %w", name, err)
 	}
 	if err := format.Node(w, fset, file); err != nil {
-		return err
+		return fmt.Errorf("%s: %w", name, err)
 	}
 	w.Close()
 
 	// Format code using goimport standard
 	bs, err := ioutil.ReadFile(path)
 	if err != nil {
-		return err
+		return fmt.Errorf("%s: %w", name, err)
 	}
 	opt := imports.Options{
 		Comments:   true,
 		FormatOnly: true,
 	}
-	bs, err = imports.Process(path, bs, &opt)
+	bs, err = imports.Process(name, bs, &opt)
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(path, bs, os.ModePerm)
+	if err := ioutil.WriteFile(path, bs, os.ModePerm); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
 }
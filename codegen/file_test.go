@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileRenderError(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Source   string
+		Expected string
+	}{
+		{"execute", `{{ template "missing" }}`, `failed to render section "test" of test/file.go`},
+		{"format", "package test\n\nfunc {", "failed to format Go source: test/file.go:3:"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "goa")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			f := &File{
+				Path:             "test/file.go",
+				SectionTemplates: []*SectionTemplate{{Name: "test", Source: c.Source}},
+			}
+			_, err = f.Render(dir)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if !strings.HasPrefix(err.Error(), c.Expected) {
+				t.Errorf("got error %q, expected it to start with %q", err, c.Expected)
+			}
+			if n := strings.Count(err.Error(), "test/file.go"); n != 1 {
+				t.Errorf("got error %q, expected it to mention the file path once, got %d times", err, n)
+			}
+			if strings.Contains(err.Error(), dir) {
+				t.Errorf("got error %q, expected it not to mention the output directory %q", err, dir)
+			}
+		})
+	}
+}
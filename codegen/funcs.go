@@ -176,6 +176,17 @@ func CamelCase(name string, firstUpper bool, acronym bool) string {
 	return string(runes)
 }
 
+// RegisterInitialisms adds the given words to the list of initialisms that
+// CamelCase (and thus Goify) keeps in upper case when acronym is true, e.g.
+// registering "SKU" makes "item_sku" produce "ItemSKU" instead of "ItemSku".
+// The words must only contain ASCII letters and digits. RegisterInitialisms
+// is intended to be called by plugins from their init function.
+func RegisterInitialisms(words ...string) {
+	for _, w := range words {
+		commonInitialisms[strings.ToUpper(w)] = true
+	}
+}
+
 // SnakeCase produces the snake_case version of the given CamelCase string.
 // News    => news
 // OldNews => old_news
@@ -71,6 +71,33 @@ func TestCamelCase(t *testing.T) {
 	}
 }
 
+func TestRegisterInitialisms(t *testing.T) {
+	if got := CamelCase("item_sku", true, true); got != "ItemSku" {
+		t.Fatalf("got %q before registering, expected %q", got, "ItemSku")
+	}
+	RegisterInitialisms("sku")
+	defer delete(commonInitialisms, "SKU")
+	cases := map[string]struct {
+		str        string
+		firstUpper bool
+		useAcronym bool
+		expected   string
+	}{
+		"first upper":     {"item_sku", true, true, "ItemSKU"},
+		"first lower":     {"item_sku", false, true, "itemSKU"},
+		"leading":         {"sku_item", false, true, "skuItem"},
+		"disable acronym": {"item_sku", true, false, "ItemSku"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			actual := CamelCase(tc.str, tc.firstUpper, tc.useAcronym)
+			if actual != tc.expected {
+				t.Errorf("got %q, expected %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestKebabCase(t *testing.T) {
 	cases := map[string]struct {
 		str      string
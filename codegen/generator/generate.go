@@ -5,17 +5,24 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/eval"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/openapi"
 	"golang.org/x/tools/go/packages"
 )
 
 // Generate runs the code generation algorithms.
 func Generate(dir, cmd string) (outputs []string, err1 error) {
-	// 1. Compute design roots.
+	// 1. Compute design roots and reset any data computed by previous runs.
 	var roots []eval.Root
 	{
+		resetData()
 		rs, err := eval.Context.Roots()
 		if err != nil {
 			return nil, err
@@ -92,15 +99,58 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		return nil, err
 	}
 
-	// 7. Write the files.
+	// 7. Write the files. The files are first rendered into a temporary
+	// directory. Once all of them have been generated successfully they are
+	// moved into place and, for the "gen" command, the stale directories
+	// under the gen directory are deleted. The files are moved one at a time
+	// so this is not atomic: if a move fails the files and directories
+	// replaced or deleted so far are restored on a best-effort basis. The
+	// temporary directories are created in the output directory (so that
+	// renaming does not cross file systems) and are left behind if the
+	// process is interrupted.
 	written := make(map[string]struct{})
-	for _, f := range genfiles {
-		filename, err := f.Render(dir)
+	{
+		base, err := filepath.Abs(dir)
 		if err != nil {
 			return nil, err
 		}
-		if filename != "" {
-			written[filename] = struct{}{}
+		tmp, err := ioutil.TempDir(base, ".goa")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(tmp)
+
+		var rendered []string
+		for _, f := range genfiles {
+			if f.SkipExist {
+				if _, err := os.Stat(filepath.Join(base, f.Path)); err == nil {
+					continue
+				}
+			}
+			filename, err := f.Render(tmp)
+			if err != nil {
+				return nil, err
+			}
+			if filename != "" {
+				rendered = append(rendered, filename)
+			}
+		}
+		var stale []string
+		if cmd == "gen" {
+			stale, err = subdirs(filepath.Join(base, codegen.Gendir))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := replaceFiles(tmp, base, stale); err != nil {
+			return nil, err
+		}
+		for _, filename := range rendered {
+			rel, err := filepath.Rel(tmp, filename)
+			if err != nil {
+				return nil, err
+			}
+			written[filepath.Join(base, rel)] = struct{}{}
 		}
 	}
 
@@ -125,3 +175,95 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 
 	return outputs, nil
 }
+
+// resetData clears the package level data structures that cache the code
+// generation data computed from the design so that calling Generate more than
+// once in the same process does not reuse stale data.
+func resetData() {
+	service.Services = make(service.ServicesData)
+	example.Servers = make(example.ServersData)
+	httpcodegen.HTTPServices = make(httpcodegen.ServicesData)
+	grpccodegen.GRPCServices = make(grpccodegen.ServicesData)
+	openapi.Definitions = make(map[string]*openapi.Schema)
+}
+
+// subdirs returns the paths of the subdirectories of dir.
+func subdirs(dir string) ([]string, error) {
+	finfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, fi := range finfos {
+		if fi.IsDir() {
+			dirs = append(dirs, filepath.Join(dir, fi.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// replaceFiles deletes the stale directories and moves all the files found
+// under the src directory to the same relative location under the dst
+// directory, creating any intermediary directory as needed. The stale
+// directories and the files being overwritten are first moved to a backup
+// directory so that they can be restored if any operation fails. Restoring
+// is best-effort: errors that occur while undoing the operations are ignored.
+func replaceFiles(src, dst string, stale []string) (err error) {
+	bak, err := ioutil.TempDir(dst, ".goa")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(bak)
+
+	var undo []func()
+	defer func() {
+		if err != nil {
+			for i := len(undo) - 1; i >= 0; i-- {
+				undo[i]()
+			}
+		}
+	}()
+	move := func(from, to string) error {
+		if err := os.MkdirAll(filepath.Dir(to), 0777); err != nil {
+			return err
+		}
+		if err := os.Rename(from, to); err != nil {
+			return err
+		}
+		undo = append(undo, func() {
+			// Remove anything left at the original location such as the
+			// directories created after a stale directory was moved away.
+			os.RemoveAll(from)
+			os.Rename(to, from)
+		})
+		return nil
+	}
+	backup := func(path string) error {
+		return move(path, filepath.Join(bak, strconv.Itoa(len(undo))))
+	}
+
+	for _, dir := range stale {
+		if err := backup(dir); err != nil {
+			return err
+		}
+	}
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if _, err := os.Stat(target); err == nil {
+			if err := backup(target); err != nil {
+				return err
+			}
+		}
+		return move(path, target)
+	})
+}
@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator/testdata"
+	"goa.design/goa/v3/eval"
+)
+
+func TestGenerateTwice(t *testing.T) {
+	dir, err := ioutil.TempDir(".", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	first := runGenerate(t, dir, testdata.AlphaMethodDSL)
+	if !strings.Contains(first, "func (c *Client) Alpha(") {
+		t.Fatalf("first run did not generate method Alpha, got:\n%s", first)
+	}
+	second := runGenerate(t, dir, testdata.BetaMethodDSL)
+	if !strings.Contains(second, "func (c *Client) Beta(") {
+		t.Errorf("second run did not generate method Beta, got:\n%s", second)
+	}
+	if strings.Contains(second, "Alpha") {
+		t.Errorf("second run generated code for method Alpha of the previous design, got:\n%s", second)
+	}
+}
+
+// runGenerate evaluates the given design, runs Generate and returns the
+// content of the generated service client file.
+func runGenerate(t *testing.T, dir string, dsl func()) string {
+	t.Helper()
+	codegen.RunDSL(t, dsl)
+	if _, err := Generate(dir, "gen"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, codegen.Gendir, "service", "client.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestGenerateFailureLeavesOutputUnchanged(t *testing.T) {
+	dir := setupGenerate(t, &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "fail", "fail.go"),
+		SectionTemplates: []*codegen.SectionTemplate{{Name: "fail", Source: `{{ template "missing" }}`}},
+	})
+	defer os.RemoveAll(dir)
+	before := snapshot(t, dir)
+
+	if _, err := Generate(dir, "gen"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	after := snapshot(t, dir)
+	if len(after) != len(before) {
+		t.Errorf("got files %v, expected %v", after, before)
+	}
+	for path, content := range before {
+		if after[path] != content {
+			t.Errorf("file %s changed from %q to %q", path, content, after[path])
+		}
+	}
+	assertNoTempDirs(t, dir)
+}
+
+func TestGenerateFailureRestoresOutput(t *testing.T) {
+	dir := setupGenerate(t,
+		&codegen.File{
+			Path:             "a.go",
+			SectionTemplates: []*codegen.SectionTemplate{{Name: "a", Source: "package a\n"}},
+		},
+		&codegen.File{
+			Path:             filepath.Join("zblocker", "b.go"),
+			SectionTemplates: []*codegen.SectionTemplate{{Name: "b", Source: "package b\n"}},
+		},
+	)
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "a.go"), "package a // existing\n")
+	// A regular file where a directory is expected makes moving the
+	// generated files fail after the stale directories have been backed up
+	// and a.go has been overwritten.
+	writeFile(t, filepath.Join(dir, "zblocker"), "blocker\n")
+	before := snapshot(t, dir)
+
+	if _, err := Generate(dir, "gen"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	after := snapshot(t, dir)
+	if len(after) != len(before) {
+		t.Errorf("got files %v, expected %v", after, before)
+	}
+	for path, content := range before {
+		if after[path] != content {
+			t.Errorf("file %s changed from %q to %q", path, content, after[path])
+		}
+	}
+	assertNoTempDirs(t, dir)
+}
+
+func TestGenerateSkipExist(t *testing.T) {
+	dir := setupGenerate(t, &codegen.File{
+		Path:             "main.go",
+		SectionTemplates: []*codegen.SectionTemplate{{Name: "main", Source: "package main\n"}},
+		SkipExist:        true,
+	})
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "main.go"), "package main // existing\n")
+
+	if _, err := Generate(dir, "gen"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "package main // existing\n" {
+		t.Errorf("existing file was overwritten with %q", string(b))
+	}
+}
+
+func TestGenerateOutputs(t *testing.T) {
+	dir := setupGenerate(t, &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "service", "service.go"),
+		SectionTemplates: []*codegen.SectionTemplate{{Name: "service", Source: "package service\n"}},
+	})
+	defer os.RemoveAll(dir)
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := Generate(dir, "gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(outputs) != 1 {
+		t.Fatalf("got %d outputs, expected 1: %v", len(outputs), outputs)
+	}
+	out, err := filepath.Abs(outputs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := filepath.Join(abs, codegen.Gendir, "service", "service.go"); out != expected {
+		t.Errorf("got output %s, expected %s", out, expected)
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("output file does not exist: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, codegen.Gendir, "stale")); !os.IsNotExist(err) {
+		t.Errorf("stale gen directory was not removed")
+	}
+}
+
+// setupGenerate evaluates a design, overrides the generators so that Generate
+// produces the given files and creates an output directory that contains a
+// stale file under the gen directory. The caller must delete the returned
+// directory.
+func setupGenerate(t *testing.T, fs ...*codegen.File) string {
+	t.Helper()
+	codegen.RunDSL(t, testdata.AlphaMethodDSL)
+	generators := Generators
+	Generators = func(string) ([]Genfunc, error) {
+		return []Genfunc{func(string, []eval.Root) ([]*codegen.File, error) {
+			return fs, nil
+		}}, nil
+	}
+	t.Cleanup(func() { Generators = generators })
+	dir, err := ioutil.TempDir(".", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, codegen.Gendir, "stale", "stale.go"), "package stale\n")
+	return dir
+}
+
+// assertNoTempDirs fails the test if dir contains any temporary directory
+// created by Generate.
+func assertNoTempDirs(t *testing.T, dir string) {
+	t.Helper()
+	finfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range finfos {
+		if strings.HasPrefix(fi.Name(), ".goa") {
+			t.Errorf("temporary directory %s was not removed", fi.Name())
+		}
+	}
+}
+
+// snapshot returns the content of all the files under dir indexed by path
+// relative to dir.
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = string(b)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
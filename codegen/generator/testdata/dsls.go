@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AlphaMethodDSL = func() {
+	Service("Service", func() {
+		Method("Alpha", func() {
+			Payload(func() {
+				Attribute("a", String)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/alpha/{a}")
+			})
+		})
+	})
+}
+
+var BetaMethodDSL = func() {
+	Service("Service", func() {
+		Method("Beta", func() {
+			Payload(func() {
+				Attribute("b", Int)
+			})
+			Result(Int)
+			HTTP(func() {
+				GET("/beta/{b}")
+			})
+		})
+	})
+}
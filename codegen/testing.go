@@ -102,7 +102,7 @@ func FormatTestCode(t *testing.T, code string) string {
 	t.Helper()
 	tmp := CreateTempFile(t, code)
 	defer os.Remove(tmp)
-	if err := finalizeGoSource(tmp); err != nil {
+	if err := finalizeGoSource(tmp, tmp); err != nil {
 		t.Fatal(err)
 	}
 	content, err := ioutil.ReadFile(tmp)
@@ -18,7 +18,9 @@ const (
 // initializes the request metadata with a unique value under the
 // RequestIDMetadata key. Optionally, it uses the incoming "x-request-id"
 // request metadata key, if present, with or without a length limit to use as
-// request ID. The default behavior is to always generate a new ID.
+// request ID. The default behavior is to always generate a new ID. The
+// request ID may also be sent back to the client in the "x-request-id"
+// response header metadata.
 //
 // examples of use:
 //  grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryRequestID()))
@@ -27,10 +29,19 @@ const (
 //  grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryRequestID(
 //    middleware.UseXRequestIDMetadataOption(true),
 //    middleware.XRequestMetadataLimitOption(128))))
+//
+//  // enable option for echoing the request ID in the response header metadata.
+//  grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryRequestID(
+//    middleware.EchoXRequestIDMetadataOption(true))))
 func UnaryRequestID(options ...middleware.RequestIDOption) grpc.UnaryServerInterceptor {
 	o := middleware.NewRequestIDOptions(options...)
 	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		ctx = generateRequestID(ctx, o)
+		if o.IsEchoRequestID() {
+			if err := grpc.SetHeader(ctx, requestIDHeader(ctx)); err != nil {
+				return nil, err
+			}
+		}
 		return handler(ctx, req)
 	})
 }
@@ -39,7 +50,9 @@ func UnaryRequestID(options ...middleware.RequestIDOption) grpc.UnaryServerInter
 // initializes the stream metadata with a unique value under the
 // RequestIDMetadata key. Optionally, it uses the incoming "x-request-id"
 // request metadata key, if present, with or without a length limit to use as
-// request ID. The default behavior is to always generate a new ID.
+// request ID. The default behavior is to always generate a new ID. The
+// request ID may also be sent back to the client in the "x-request-id"
+// response header metadata.
 //
 // examples of use:
 //  grpc.NewServer(grpc.UnaryInterceptor(middleware.StreamRequestID()))
@@ -48,10 +61,19 @@ func UnaryRequestID(options ...middleware.RequestIDOption) grpc.UnaryServerInter
 //  grpc.NewServer(grpc.UnaryInterceptor(middleware.StreamRequestID(
 //    middleware.UseXRequestIDMetadataOption(true),
 //    middleware.XRequestMetadataLimitOption(128))))
+//
+//  // enable option for echoing the request ID in the response header metadata.
+//  grpc.NewServer(grpc.UnaryInterceptor(middleware.StreamRequestID(
+//    middleware.EchoXRequestIDMetadataOption(true))))
 func StreamRequestID(options ...middleware.RequestIDOption) grpc.StreamServerInterceptor {
 	o := middleware.NewRequestIDOptions(options...)
 	return grpc.StreamServerInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		ctx := generateRequestID(ss.Context(), o)
+		if o.IsEchoRequestID() {
+			if err := ss.SetHeader(requestIDHeader(ctx)); err != nil {
+				return err
+			}
+		}
 		wss := NewWrappedServerStream(ctx, ss)
 		return handler(srv, wss)
 	})
@@ -68,6 +90,12 @@ func XRequestMetadataLimitOption(limit int) middleware.RequestIDOption {
 	return middleware.RequestIDLimitOption(limit)
 }
 
+// EchoXRequestIDMetadataOption enables/disables sending the request ID back
+// to the client in the "x-request-id" response header metadata.
+func EchoXRequestIDMetadataOption(f bool) middleware.RequestIDOption {
+	return middleware.EchoRequestIDOption(f)
+}
+
 // generateRequestID sets the request ID in the incoming request metadata.
 func generateRequestID(ctx context.Context, opts *middleware.RequestIDOptions) context.Context {
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -83,3 +111,9 @@ func generateRequestID(ctx context.Context, opts *middleware.RequestIDOptions) c
 	md.Set(RequestIDMetadataKey, ctx.Value(middleware.RequestIDKey).(string))
 	return metadata.NewIncomingContext(ctx, md)
 }
+
+// requestIDHeader returns the response header metadata containing the request
+// ID stored in the given context.
+func requestIDHeader(ctx context.Context) metadata.MD {
+	return metadata.Pairs(RequestIDMetadataKey, ctx.Value(middleware.RequestIDKey).(string))
+}
@@ -15,6 +15,20 @@ type (
 	testServerStream struct {
 		grpc.ServerStream
 	}
+
+	// headerServerStream records the response header metadata set by the
+	// stream interceptors.
+	headerServerStream struct {
+		grpc.ServerStream
+		ctx    context.Context
+		header metadata.MD
+	}
+
+	// headerTransportStream records the response header metadata set by the
+	// unary interceptors.
+	headerTransportStream struct {
+		header metadata.MD
+	}
 )
 
 func TestUnaryRequestID(t *testing.T) {
@@ -192,6 +206,89 @@ func TestStreamRequestID(t *testing.T) {
 	}
 }
 
+func TestRequestIDEcho(t *testing.T) {
+	var (
+		unary = &grpc.UnaryServerInfo{
+			FullMethod: "Test.Test",
+		}
+		stream = &grpc.StreamServerInfo{
+			FullMethod: "Test.Test",
+		}
+		id = "xyz"
+	)
+	cases := []struct {
+		name     string
+		options  []middleware.RequestIDOption
+		expected []string
+	}{
+		{"default", nil, nil},
+		{"echo", []middleware.RequestIDOption{grpcm.UseXRequestIDMetadataOption(true), grpcm.EchoXRequestIDMetadataOption(true)}, []string{id}},
+	}
+
+	for _, c := range cases {
+		t.Run("unary-"+c.name, func(t *testing.T) {
+			ts := &headerTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(populateRequestID(id), ts)
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "response", nil }
+			if _, err := grpcm.UnaryRequestID(c.options...)(ctx, "request", unary, handler); err != nil {
+				t.Fatalf("UnaryRequestID error: %v", err)
+			}
+			if actual := ts.header.Get(grpcm.RequestIDMetadataKey); !equal(actual, c.expected) {
+				t.Errorf("got response header metadata %v, expected %v", actual, c.expected)
+			}
+		})
+		t.Run("stream-"+c.name, func(t *testing.T) {
+			ss := &headerServerStream{ctx: populateRequestID(id)}
+			handler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+			if err := grpcm.StreamRequestID(c.options...)(nil, ss, stream, handler); err != nil {
+				t.Fatalf("StreamRequestID error: %v", err)
+			}
+			if actual := ss.header.Get(grpcm.RequestIDMetadataKey); !equal(actual, c.expected) {
+				t.Errorf("got response header metadata %v, expected %v", actual, c.expected)
+			}
+		})
+	}
+}
+
+func (s *headerServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *headerServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerTransportStream) Method() string {
+	return "Test.Test"
+}
+
+func (s *headerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *headerTransportStream) SetTrailer(md metadata.MD) error {
+	return nil
+}
+
+// equal returns true if a and b contain the same values in the same order.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // populateRequestID populates the context with incoming gRPC request metadata
 // containing the RequestIDMetadataKey key set to the given ID.
 func populateRequestID(id string) context.Context {
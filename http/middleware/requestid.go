@@ -10,7 +10,8 @@ import (
 // RequestID returns a middleware, which initializes the context with a unique
 // value under the RequestIDKey key. Optionally uses the incoming "X-Request-Id"
 // header, if present, with or without a length limit to use as request ID. the
-// default behavior is to always generate a new ID.
+// default behavior is to always generate a new ID. Optionally writes the
+// request ID back to the client in the "X-Request-Id" response header.
 //
 // examples of use:
 //  service.Use(middleware.RequestID())
@@ -19,6 +20,10 @@ import (
 //  service.Use(middleware.RequestID(
 //    middleware.UseXRequestIDHeaderOption(true),
 //    middleware.XRequestHeaderLimitOption(128)))
+//
+//  // echo the request ID in the "X-Request-Id" response header.
+//  service.Use(middleware.RequestID(
+//    middleware.EchoXRequestIDHeaderOption(true)))
 func RequestID(options ...middleware.RequestIDOption) func(http.Handler) http.Handler {
 	o := middleware.NewRequestIDOptions(options...)
 	return func(h http.Handler) http.Handler {
@@ -30,6 +35,9 @@ func RequestID(options ...middleware.RequestIDOption) func(http.Handler) http.Ha
 				}
 			}
 			ctx = middleware.GenerateRequestID(ctx, o)
+			if o.IsEchoRequestID() {
+				w.Header().Set("X-Request-Id", ctx.Value(middleware.RequestIDKey).(string))
+			}
 			h.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -44,3 +52,9 @@ func UseXRequestIDHeaderOption(f bool) middleware.RequestIDOption {
 func XRequestHeaderLimitOption(limit int) middleware.RequestIDOption {
 	return middleware.RequestIDLimitOption(limit)
 }
+
+// EchoXRequestIDHeaderOption enables/disables writing the request ID in the
+// "X-Request-Id" response header.
+func EchoXRequestIDHeaderOption(f bool) middleware.RequestIDOption {
+	return middleware.EchoRequestIDOption(f)
+}
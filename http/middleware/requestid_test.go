@@ -2,6 +2,7 @@ package middleware_test
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	httpm "goa.design/goa/v3/http/middleware"
@@ -37,7 +38,6 @@ func TestRequestID(t *testing.T) {
 			}
 			return
 		}
-		ignored http.ResponseWriter
 	)
 	for _, tc := range []*requestIDTestCase{
 		{"default without header", nil, makeRequest(""),
@@ -89,9 +89,40 @@ func TestRequestID(t *testing.T) {
 				}
 			},
 		},
+		{"no echo by default",
+			[]middleware.RequestIDOption{httpm.UseXRequestIDHeaderOption(true)},
+			makeRequest("accept+header"),
+			func(w http.ResponseWriter, r *http.Request) {
+				if h := w.Header().Get("X-Request-Id"); h != "" {
+					t.Errorf("%s: unexpected response header value: %s", r.Header.Get("Test-Case"), h)
+				}
+			},
+		},
+		{"echo header",
+			[]middleware.RequestIDOption{httpm.EchoXRequestIDHeaderOption(true)},
+			makeRequest(""),
+			func(w http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if h := w.Header().Get("X-Request-Id"); h != id {
+					t.Errorf("%s: unexpected response header value: %s != %s", r.Header.Get("Test-Case"), h, id)
+				}
+			},
+		},
+		{"echo accepted header",
+			[]middleware.RequestIDOption{
+				httpm.UseXRequestIDHeaderOption(true),
+				httpm.EchoXRequestIDHeaderOption(true),
+			},
+			makeRequest("accept+header"),
+			func(w http.ResponseWriter, r *http.Request) {
+				if h := w.Header().Get("X-Request-Id"); h != "accept+header" {
+					t.Errorf("%s: unexpected response header value: %s != accept+header", r.Header.Get("Test-Case"), h)
+				}
+			},
+		},
 	} {
 		httpm.RequestID(tc.options...)(
-			&requestIDTestHandler{tc.name, tc.handler}).ServeHTTP(ignored, tc.request)
+			&requestIDTestHandler{tc.name, tc.handler}).ServeHTTP(httptest.NewRecorder(), tc.request)
 	}
 }
 
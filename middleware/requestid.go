@@ -20,6 +20,9 @@ type (
 		// requestIDLimit if positive truncates the request ID at the specified
 		// length. Defaults to no limit.
 		requestIDLimit int
+		// echoRequestID if true indicates the middleware to write the
+		// request ID back to the client in the response. Defaults to false.
+		echoRequestID bool
 	}
 )
 
@@ -71,11 +74,26 @@ func RequestIDLimitOption(limit int) RequestIDOption {
 	}
 }
 
+// EchoRequestIDOption enables/disables writing the request ID back to the
+// client in the response: the "X-Request-Id" HTTP response header or the
+// "x-request-id" gRPC response header metadata.
+func EchoRequestIDOption(f bool) RequestIDOption {
+	return func(o *RequestIDOptions) *RequestIDOptions {
+		o.echoRequestID = f
+		return o
+	}
+}
+
 // IsUseRequestID returns the request ID option.
 func (o *RequestIDOptions) IsUseRequestID() bool {
 	return o.useRequestID
 }
 
+// IsEchoRequestID returns the echo request ID option.
+func (o *RequestIDOptions) IsEchoRequestID() bool {
+	return o.echoRequestID
+}
+
 // shortID produces a " unique" 6 bytes long string.
 // Do not use as a reliable way to get unique IDs, instead use for things like logging.
 func shortID() string {